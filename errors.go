@@ -0,0 +1,65 @@
+package withings
+
+import (
+	"fmt"
+	"time"
+)
+
+// APIError is returned when the Withings API responds with a non-zero
+// status in an otherwise successful (HTTP 200) response.
+type APIError struct {
+	Status int
+	Err    string
+	Action string
+}
+
+func (e *APIError) Error() string {
+	if e.Err != "" {
+		return fmt.Sprintf("withings: %s: status %d: %s", e.Action, e.Status, e.Err)
+	}
+	return fmt.Sprintf("withings: %s: status %d", e.Action, e.Status)
+}
+
+// Is reports whether target is an *APIError with the same Status, so
+// sentinel errors such as ErrRateLimited can be compared with errors.Is
+// regardless of Action or Err.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return e.Status == t.Status
+}
+
+// Sentinel Withings API statuses, usable with errors.Is(err, ...).
+var (
+	ErrInvalidToken  = &APIError{Status: 100}
+	ErrInvalidParams = &APIError{Status: 293}
+	ErrRateLimited   = &APIError{Status: 601}
+)
+
+// RetryPolicy controls whether and how httpClient.get retries a request
+// after it fails with ErrRateLimited; other API errors are not retried.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts made after the
+	// first failed request.
+	MaxRetries int
+
+	// Backoff returns how long to wait before the given retry
+	// (1-indexed, so 1 is the wait before the second request). If nil,
+	// DefaultBackoff is used.
+	Backoff func(attempt int) time.Duration
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.Backoff != nil {
+		return p.Backoff(attempt)
+	}
+	return DefaultBackoff(attempt)
+}
+
+// DefaultBackoff waits 500ms, 1s, 2s, 4s, ... between retries.
+func DefaultBackoff(attempt int) time.Duration {
+	return 500 * time.Millisecond * time.Duration(1<<uint(attempt-1))
+}
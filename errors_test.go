@@ -0,0 +1,117 @@
+package withings_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	withings "github.com/nasa9084/go-withings"
+	"golang.org/x/xerrors"
+)
+
+func TestGetMeasDecodesAPIError(t *testing.T) {
+	srv := httptest.NewServer(handler(t, `{"status":100,"error":"invalid access token"}`))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	_, err := c.Measure().GetMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), 0, time.Now())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(err, withings.ErrInvalidToken) {
+		t.Errorf("expected err to be ErrInvalidToken, got %v", err)
+	}
+
+	var apiErr *withings.APIError
+	if !xerrors.As(err, &apiErr) {
+		t.Fatalf("expected err to be an *APIError, got %T", err)
+	}
+	if apiErr.Err != "invalid access token" {
+		t.Errorf("unexpected APIError.Err: %q", apiErr.Err)
+	}
+}
+
+func TestGetRetriesOnRateLimit(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+		if attempts < 3 {
+			w.Write([]byte(`{"status":601,"error":"too many requests"}`))
+			return
+		}
+		w.Write([]byte(MeasureGetMeasSuccessResponse))
+	}))
+	defer srv.Close()
+
+	c := withings.New(
+		withings.WithEndpoint(srv.URL),
+		withings.WithHTTPClient(httpClient),
+		withings.WithRetryPolicy(withings.RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    func(attempt int) time.Duration { return time.Millisecond },
+		}),
+	)
+	_, err := c.Measure().GetMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestGetGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":601,"error":"too many requests"}`))
+	}))
+	defer srv.Close()
+
+	c := withings.New(
+		withings.WithEndpoint(srv.URL),
+		withings.WithHTTPClient(httpClient),
+		withings.WithRetryPolicy(withings.RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    func(attempt int) time.Duration { return time.Millisecond },
+		}),
+	)
+	_, err := c.Measure().GetMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), 0, time.Now())
+	if !errors.Is(err, withings.ErrRateLimited) {
+		t.Errorf("expected err to be ErrRateLimited, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 1 initial attempt + 2 retries = 3, got %d", attempts)
+	}
+}
+
+func TestGetDoesNotRetryNonRetryableError(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":100,"error":"invalid access token"}`))
+	}))
+	defer srv.Close()
+
+	c := withings.New(
+		withings.WithEndpoint(srv.URL),
+		withings.WithHTTPClient(httpClient),
+		withings.WithRetryPolicy(withings.RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    func(attempt int) time.Duration { return time.Millisecond },
+		}),
+	)
+	_, err := c.Measure().GetMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), 0, time.Now())
+	if !errors.Is(err, withings.ErrInvalidToken) {
+		t.Errorf("expected err to be ErrInvalidToken, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retries for a non-retryable error, got %d attempts", attempts)
+	}
+}
@@ -0,0 +1,336 @@
+package withings
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// DefaultMaxPages bounds how many pages an iterator will fetch before
+// stopping even if the API still reports more results.
+const DefaultMaxPages = 1000
+
+// MeasIterator iterates over the measure groups of a GetMeas call,
+// fetching the next page as needed.
+type MeasIterator struct {
+	svc      *MeasureService
+	ctx      context.Context
+	meastype MeasureType
+	category MeasureCategory
+	start    time.Time
+	end      time.Time
+	lastupd  time.Time
+	maxPages int
+
+	groups  []MeasureGroup
+	idx     int
+	offset  int
+	more    bool
+	page    int
+	started bool
+	err     error
+}
+
+// IterMeas returns a MeasIterator over the measure groups matching the
+// given parameters.
+func (svc *MeasureService) IterMeas(ctx context.Context, meastype MeasureType, category MeasureCategory, startdate, enddate time.Time, lastupdate time.Time) *MeasIterator {
+	return &MeasIterator{
+		svc:      svc,
+		ctx:      ctx,
+		meastype: meastype,
+		category: category,
+		start:    startdate,
+		end:      enddate,
+		lastupd:  lastupdate,
+		maxPages: DefaultMaxPages,
+	}
+}
+
+// WithMaxPages overrides the page cap used to bound how many requests
+// Next will issue.
+func (it *MeasIterator) WithMaxPages(n int) *MeasIterator {
+	it.maxPages = n
+	return it
+}
+
+// Next advances the iterator, fetching the next page of results when the
+// current one is exhausted. It returns false when there is nothing left
+// to iterate or an error occurred.
+func (it *MeasIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.idx < len(it.groups) {
+		it.idx++
+		return true
+	}
+	if it.started && !it.more {
+		return false
+	}
+	if it.started && it.page >= it.maxPages {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	resp, err := it.svc.GetMeas(it.ctx, it.meastype, it.category, it.start, it.end, it.offset, it.lastupd)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.started = true
+	it.page++
+	it.groups = resp.Body.MeasureGroups
+	it.offset = resp.Body.Offset
+	it.more = resp.Body.More
+	it.idx = 0
+
+	if len(it.groups) == 0 {
+		return false
+	}
+	it.idx++
+	return true
+}
+
+// Value returns the MeasureGroup at the iterator's current position. It
+// must only be called after a call to Next returns true.
+func (it *MeasIterator) Value() MeasureGroup {
+	return it.groups[it.idx-1]
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *MeasIterator) Err() error {
+	return it.err
+}
+
+// AllMeas collects every MeasureGroup across all pages matching the given
+// parameters, re-issuing requests with the returned offset until more is
+// false.
+func (svc *MeasureService) AllMeas(ctx context.Context, meastype MeasureType, category MeasureCategory, startdate, enddate time.Time, lastupdate time.Time) ([]MeasureGroup, error) {
+	it := svc.IterMeas(ctx, meastype, category, startdate, enddate, lastupdate)
+	var groups []MeasureGroup
+	for it.Next() {
+		groups = append(groups, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating measures: %w", err)
+	}
+	return groups, nil
+}
+
+// ActivityIterator iterates over the activities of a GetActivity call,
+// fetching the next page as needed.
+type ActivityIterator struct {
+	svc        *MeasureService
+	ctx        context.Context
+	start      time.Time
+	end        time.Time
+	dataFields []ActivityDataField
+	lastupd    time.Time
+	maxPages   int
+
+	activities []Activity
+	idx        int
+	offset     int
+	more       bool
+	page       int
+	started    bool
+	err        error
+}
+
+// IterActivity returns an ActivityIterator over the activities matching
+// the given parameters.
+func (svc *MeasureService) IterActivity(ctx context.Context, startdate, enddate time.Time, dataFields []ActivityDataField, lastupdate time.Time) *ActivityIterator {
+	return &ActivityIterator{
+		svc:        svc,
+		ctx:        ctx,
+		start:      startdate,
+		end:        enddate,
+		dataFields: dataFields,
+		lastupd:    lastupdate,
+		maxPages:   DefaultMaxPages,
+	}
+}
+
+// WithMaxPages overrides the page cap used to bound how many requests
+// Next will issue.
+func (it *ActivityIterator) WithMaxPages(n int) *ActivityIterator {
+	it.maxPages = n
+	return it
+}
+
+// Next advances the iterator, fetching the next page of results when the
+// current one is exhausted.
+func (it *ActivityIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.idx < len(it.activities) {
+		it.idx++
+		return true
+	}
+	if it.started && !it.more {
+		return false
+	}
+	if it.started && it.page >= it.maxPages {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	resp, err := it.svc.GetActivity(it.ctx, it.start, it.end, it.offset, it.dataFields, it.lastupd)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.started = true
+	it.page++
+	it.activities = resp.Body.Activities
+	it.offset = resp.Body.Offset
+	it.more = resp.Body.More
+	it.idx = 0
+
+	if len(it.activities) == 0 {
+		return false
+	}
+	it.idx++
+	return true
+}
+
+// Value returns the Activity at the iterator's current position. It must
+// only be called after a call to Next returns true.
+func (it *ActivityIterator) Value() Activity {
+	return it.activities[it.idx-1]
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *ActivityIterator) Err() error {
+	return it.err
+}
+
+// AllActivity collects every Activity across all pages matching the
+// given parameters, re-issuing requests with the returned offset until
+// more is false.
+func (svc *MeasureService) AllActivity(ctx context.Context, startdate, enddate time.Time, dataFields []ActivityDataField, lastupdate time.Time) ([]Activity, error) {
+	it := svc.IterActivity(ctx, startdate, enddate, dataFields, lastupdate)
+	var activities []Activity
+	for it.Next() {
+		activities = append(activities, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating activities: %w", err)
+	}
+	return activities, nil
+}
+
+// SleepSummaryIterator iterates over the summaries of a GetSleepSummary
+// call, fetching the next page as needed.
+type SleepSummaryIterator struct {
+	svc        *SleepService
+	ctx        context.Context
+	start      time.Time
+	end        time.Time
+	lastupd    time.Time
+	dataFields []SleepSummaryDataField
+	maxPages   int
+
+	summaries []SleepSummary
+	idx       int
+	offset    int
+	more      bool
+	page      int
+	started   bool
+	err       error
+}
+
+// IterSleepSummary returns a SleepSummaryIterator over the summaries
+// matching the given parameters.
+func (svc *SleepService) IterSleepSummary(ctx context.Context, startdateymd, enddateymd, lastupdate time.Time, dataFields []SleepSummaryDataField) *SleepSummaryIterator {
+	return &SleepSummaryIterator{
+		svc:        svc,
+		ctx:        ctx,
+		start:      startdateymd,
+		end:        enddateymd,
+		lastupd:    lastupdate,
+		dataFields: dataFields,
+		maxPages:   DefaultMaxPages,
+	}
+}
+
+// WithMaxPages overrides the page cap used to bound how many requests
+// Next will issue.
+func (it *SleepSummaryIterator) WithMaxPages(n int) *SleepSummaryIterator {
+	it.maxPages = n
+	return it
+}
+
+// Next advances the iterator, fetching the next page of results when the
+// current one is exhausted.
+func (it *SleepSummaryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.idx < len(it.summaries) {
+		it.idx++
+		return true
+	}
+	if it.started && !it.more {
+		return false
+	}
+	if it.started && it.page >= it.maxPages {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	resp, err := it.svc.GetSleepSummary(it.ctx, it.start, it.end, it.offset, it.dataFields, it.lastupd)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.started = true
+	it.page++
+	it.summaries = resp.Body.Series
+	it.offset = resp.Body.Offset
+	it.more = resp.Body.More
+	it.idx = 0
+
+	if len(it.summaries) == 0 {
+		return false
+	}
+	it.idx++
+	return true
+}
+
+// Value returns the SleepSummary at the iterator's current position. It
+// must only be called after a call to Next returns true.
+func (it *SleepSummaryIterator) Value() SleepSummary {
+	return it.summaries[it.idx-1]
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *SleepSummaryIterator) Err() error {
+	return it.err
+}
+
+// AllSleepSummary collects every SleepSummary across all pages matching
+// the given parameters, re-issuing requests with the returned offset
+// until more is false.
+func (svc *SleepService) AllSleepSummary(ctx context.Context, startdateymd, enddateymd, lastupdate time.Time, dataFields []SleepSummaryDataField) ([]SleepSummary, error) {
+	it := svc.IterSleepSummary(ctx, startdateymd, enddateymd, lastupdate, dataFields)
+	var summaries []SleepSummary
+	for it.Next() {
+		summaries = append(summaries, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, xerrors.Errorf("iterating sleep summaries: %w", err)
+	}
+	return summaries, nil
+}
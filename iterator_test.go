@@ -0,0 +1,133 @@
+package withings_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	withings "github.com/nasa9084/go-withings"
+)
+
+// pagedHandler serves n pages of a single JSON item at a time, keyed by
+// the offset query param, so pagination can be exercised without a real
+// Withings backend.
+func pagedHandler(t *testing.T, pages []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token == "" {
+			t.Fatal("Authorization header is empty or undefined")
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset < 0 || offset >= len(pages) {
+			t.Fatalf("unexpected offset %d", offset)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, pages[offset])
+	})
+}
+
+func TestMeasIteratorPaginates(t *testing.T) {
+	pages := []string{
+		`{"status":0,"body":{"measuregrps":[{"grpid":1,"measures":[{"value":1,"type":1}]}],"more":true,"offset":1}}`,
+		`{"status":0,"body":{"measuregrps":[{"grpid":2,"measures":[{"value":2,"type":1}]}],"more":false,"offset":2}}`,
+	}
+	srv := httptest.NewServer(pagedHandler(t, pages))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	groups, err := c.Measure().AllMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups across both pages, got %d", len(groups))
+	}
+	if groups[0].GroupID != 1 || groups[1].GroupID != 2 {
+		t.Errorf("unexpected group order: %+v", groups)
+	}
+}
+
+func TestMeasIteratorWithMaxPages(t *testing.T) {
+	pages := []string{
+		`{"status":0,"body":{"measuregrps":[{"grpid":1,"measures":[{"value":1,"type":1}]}],"more":true,"offset":1}}`,
+		`{"status":0,"body":{"measuregrps":[{"grpid":2,"measures":[{"value":2,"type":1}]}],"more":true,"offset":2}}`,
+	}
+	srv := httptest.NewServer(pagedHandler(t, pages))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	it := c.Measure().IterMeas(context.Background(), withings.Weight, withings.RealMeasure, time.Now(), time.Now(), time.Time{}).WithMaxPages(1)
+
+	var groups []withings.MeasureGroup
+	for it.Next() {
+		groups = append(groups, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected iteration to stop after 1 page, got %d groups", len(groups))
+	}
+}
+
+func TestActivityIteratorPaginates(t *testing.T) {
+	pages := []string{
+		`{"status":0,"body":{"activities":[{"date":"2024-01-01","steps":100}],"more":true,"offset":1}}`,
+		`{"status":0,"body":{"activities":[{"date":"2024-01-02","steps":200}],"more":false,"offset":2}}`,
+	}
+	srv := httptest.NewServer(pagedHandler(t, pages))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	activities, err := c.Measure().AllActivity(context.Background(), time.Now(), time.Now(), []withings.ActivityDataField{withings.Steps}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 2 {
+		t.Fatalf("expected 2 activities across both pages, got %d", len(activities))
+	}
+	if activities[0].Steps != 100 || activities[1].Steps != 200 {
+		t.Errorf("unexpected activities: %+v", activities)
+	}
+}
+
+func TestSleepSummaryIteratorPaginates(t *testing.T) {
+	pages := []string{
+		`{"status":0,"body":{"series":[{"id":1,"data":{"sleep_score":80}}],"more":true,"offset":1}}`,
+		`{"status":0,"body":{"series":[{"id":2,"data":{"sleep_score":90}}],"more":false,"offset":2}}`,
+	}
+	srv := httptest.NewServer(pagedHandler(t, pages))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	summaries, err := c.Sleep().AllSleepSummary(context.Background(), time.Now(), time.Now(), time.Time{}, []withings.SleepSummaryDataField{withings.SleepScore})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries across both pages, got %d", len(summaries))
+	}
+	if summaries[0].ID != 1 || summaries[1].ID != 2 {
+		t.Errorf("unexpected summaries: %+v", summaries)
+	}
+}
+
+func TestMeasIteratorStopsOnContextCancellation(t *testing.T) {
+	pages := []string{
+		`{"status":0,"body":{"measuregrps":[{"grpid":1,"measures":[{"value":1,"type":1}]}],"more":true,"offset":1}}`,
+	}
+	srv := httptest.NewServer(pagedHandler(t, pages))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	_, err := c.Measure().AllMeas(ctx, withings.Weight, withings.RealMeasure, time.Now(), time.Now(), time.Time{})
+	if err == nil {
+		t.Fatal("expected an error from a cancelled context")
+	}
+}
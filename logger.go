@@ -0,0 +1,48 @@
+package withings
+
+import "log"
+
+// Logger receives diagnostic messages from a Client: the outgoing
+// request, the decoded Withings API status and latency, and any error
+// encountered. The default, installed unless WithLogger is given, is a
+// no-op.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// ensure nilLogger implements Logger interface
+var _ Logger = (*nilLogger)(nil)
+
+type nilLogger struct{}
+
+func (nilLogger) Debugf(format string, args ...interface{}) {}
+func (nilLogger) Infof(format string, args ...interface{})  {}
+func (nilLogger) Errorf(format string, args ...interface{}) {}
+
+// ensure StdLogger implements Logger interface
+var _ Logger = (*StdLogger)(nil)
+
+// StdLogger adapts a *log.Logger to the Logger interface, prefixing
+// every line with its severity.
+type StdLogger struct {
+	*log.Logger
+}
+
+// NewStdLogger returns a Logger which writes to l.
+func NewStdLogger(l *log.Logger) *StdLogger {
+	return &StdLogger{Logger: l}
+}
+
+func (l *StdLogger) Debugf(format string, args ...interface{}) {
+	l.Printf("DEBUG "+format, args...)
+}
+
+func (l *StdLogger) Infof(format string, args ...interface{}) {
+	l.Printf("INFO "+format, args...)
+}
+
+func (l *StdLogger) Errorf(format string, args ...interface{}) {
+	l.Printf("ERROR "+format, args...)
+}
@@ -0,0 +1,31 @@
+package withings
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// ensure SlogLogger implements Logger interface
+var _ Logger = (*SlogLogger)(nil)
+
+// SlogLogger adapts a *slog.Logger to the Logger interface.
+type SlogLogger struct {
+	*slog.Logger
+}
+
+// NewSlogLogger returns a Logger which writes to l.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	return &SlogLogger{Logger: l}
+}
+
+func (l *SlogLogger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Infof(format string, args ...interface{}) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Errorf(format string, args ...interface{}) {
+	l.Error(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,82 @@
+package withings_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	withings "github.com/nasa9084/go-withings"
+)
+
+type recordingLogger struct {
+	debugf []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.debugf = append(l.debugf, fmt.Sprintf(format, args...))
+}
+func (l *recordingLogger) Infof(format string, args ...interface{})  {}
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {}
+
+func TestStdLoggerPrefixesSeverity(t *testing.T) {
+	var buf bytes.Buffer
+	l := withings.NewStdLogger(log.New(&buf, "", 0))
+
+	l.Debugf("fetching %s", "measures")
+	l.Infof("status %d", 0)
+	l.Errorf("failed: %v", "boom")
+
+	got := buf.String()
+	for _, want := range []string{"DEBUG fetching measures", "INFO status 0", "ERROR failed: boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected log output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestSlogLoggerWritesMessages(t *testing.T) {
+	var buf bytes.Buffer
+	l := withings.NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	l.Debugf("fetching %s", "measures")
+	l.Infof("status %d", 0)
+	l.Errorf("failed: %v", "boom")
+
+	got := buf.String()
+	for _, want := range []string{"fetching measures", "status 0", "failed: boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected log output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestLoggerDoesNotLeakSubscribeSignatureAndNonce(t *testing.T) {
+	srv := httptest.NewServer(handler(t, `{"status":0}`))
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient), withings.WithLogger(logger))
+
+	const secretSignature = "s3cr3t-signature"
+	const secretNonce = "s3cr3t-nonce"
+	if err := c.Notify().Subscribe(context.Background(), "https://example.com/callback", withings.WeightAppli, "", secretSignature, secretNonce); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.debugf) == 0 {
+		t.Fatal("expected at least one Debugf call logging the request")
+	}
+	for _, line := range logger.debugf {
+		if strings.Contains(line, secretSignature) || strings.Contains(line, secretNonce) {
+			t.Errorf("logged request leaked secret: %s", line)
+		}
+		if !strings.Contains(line, "REDACTED") {
+			t.Errorf("expected logged request to mask the secret with REDACTED, got: %s", line)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// DefaultMultiMeasConcurrency is the concurrency GetAll uses when
+// MultiMeasRequest.Concurrency is 0.
+const DefaultMultiMeasConcurrency = 4
+
+// MultiMeasRequest describes a GetAll call: one GetMeas fetch per
+// MeasType, all sharing the same date window.
+type MultiMeasRequest struct {
+	MeasTypes  []MeasureType
+	Category   MeasureCategory
+	Startdate  time.Time
+	Enddate    time.Time
+	Lastupdate time.Time
+
+	// Concurrency bounds how many MeasTypes are fetched at once. 0 means
+	// DefaultMultiMeasConcurrency.
+	Concurrency int
+}
+
+// MultiMeasResult is the aggregated result of a GetAll call.
+type MultiMeasResult struct {
+	// MeasureGroups holds one entry per distinct GroupID seen across all
+	// requested MeasTypes, with the Measures from every fetch that
+	// returned that GroupID merged into it, since a single reading often
+	// reports several MeasTypes under the same group.
+	MeasureGroups []MeasureGroup
+}
+
+// GetAll fetches every MeasureType in req concurrently, paging each one
+// to completion via AllMeas, and aggregates the results into one
+// MeasureGroup per GroupID, merging the Measures reported under it. It
+// respects ctx cancellation and returns whatever was fetched before any
+// failures alongside a joined error describing them.
+func (svc *MeasureService) GetAll(ctx context.Context, req MultiMeasRequest) (MultiMeasResult, error) {
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMultiMeasConcurrency
+	}
+
+	type fetchResult struct {
+		meastype MeasureType
+		groups   []MeasureGroup
+		err      error
+	}
+
+	sem := make(chan struct{}, concurrency)
+	results := make(chan fetchResult, len(req.MeasTypes))
+	var wg sync.WaitGroup
+	for _, meastype := range req.MeasTypes {
+		meastype := meastype
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results <- fetchResult{meastype: meastype, err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			groups, err := svc.AllMeas(ctx, meastype, req.Category, req.Startdate, req.Enddate, req.Lastupdate)
+			results <- fetchResult{meastype: meastype, groups: groups, err: err}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	index := make(map[int]int)
+	var merged MultiMeasResult
+	var errs []error
+	for res := range results {
+		if res.err != nil {
+			errs = append(errs, xerrors.Errorf("fetching meastype %d: %w", res.meastype, res.err))
+			continue
+		}
+		for _, g := range res.groups {
+			if i, ok := index[g.GroupID]; ok {
+				merged.MeasureGroups[i].Measures = append(merged.MeasureGroups[i].Measures, g.Measures...)
+				continue
+			}
+			index[g.GroupID] = len(merged.MeasureGroups)
+			merged.MeasureGroups = append(merged.MeasureGroups, g)
+		}
+	}
+
+	if len(errs) > 0 {
+		return merged, errors.Join(errs...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,88 @@
+package withings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	withings "github.com/nasa9084/go-withings"
+)
+
+func multiMeasHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token == "" {
+			t.Fatal("Authorization header is empty or undefined")
+		}
+
+		switch r.URL.Query().Get("meastype") {
+		case "1": // Weight
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"attrib":0,"date":0,"created":0,"category":1,"deviceid":"dev","measures":[{"value":700,"type":1,"unit":-2,"algo":0,"fm":0,"fw":0}],"comment":""}],"more":false,"offset":0}}`)
+		case "6": // FatRatio
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"attrib":0,"date":0,"created":0,"category":1,"deviceid":"dev","measures":[{"value":210,"type":6,"unit":-1,"algo":0,"fm":0,"fw":0}],"comment":""}],"more":false,"offset":0}}`)
+		case "293": // simulates an invalid-params failure for one MeasType
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"status":293,"error":"invalid params"}`)
+		default:
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[],"more":false,"offset":0}}`)
+		}
+	})
+}
+
+func TestMeasureServiceGetAll(t *testing.T) {
+	srv := httptest.NewServer(multiMeasHandler(t))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+
+	t.Run("merges measures reported under the same GroupID", func(t *testing.T) {
+		result, err := c.Measure().GetAll(context.Background(), withings.MultiMeasRequest{
+			MeasTypes: []withings.MeasureType{withings.Weight, withings.FatRatio},
+			Category:  withings.RealMeasure,
+			Startdate: time.Now(),
+			Enddate:   time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.MeasureGroups) != 1 {
+			t.Fatalf("expected 1 merged group, got %d", len(result.MeasureGroups))
+		}
+
+		measures := result.MeasureGroups[0].Measures
+		if len(measures) != 2 {
+			t.Fatalf("expected 2 merged measures, got %d", len(measures))
+		}
+		sort.Slice(measures, func(i, j int) bool { return measures[i].Type < measures[j].Type })
+		want := []withings.Measure{
+			{Value: 700, Type: withings.Weight, Unit: -2},
+			{Value: 210, Type: withings.FatRatio, Unit: -1},
+		}
+		assertEqual(t, measures, want)
+	})
+
+	t.Run("returns partial results alongside a joined error on failure", func(t *testing.T) {
+		result, err := c.Measure().GetAll(context.Background(), withings.MultiMeasRequest{
+			MeasTypes: []withings.MeasureType{withings.Weight, withings.MeasureType(293)},
+			Category:  withings.RealMeasure,
+			Startdate: time.Now(),
+			Enddate:   time.Now(),
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !errors.Is(err, withings.ErrInvalidParams) {
+			t.Errorf("expected error to wrap ErrInvalidParams, got %v", err)
+		}
+		if len(result.MeasureGroups) != 1 {
+			t.Fatalf("expected 1 partial group, got %d", len(result.MeasureGroups))
+		}
+	})
+}
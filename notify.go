@@ -0,0 +1,328 @@
+package withings
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+func (c *Client) Notify() *NotifyService { return c.notify }
+
+// Appli is a notification category, selecting which kind of data changes
+// a subscription should be notified about.
+type Appli int
+
+// Appli Constants.
+const (
+	UnknownAppli     Appli = 0
+	WeightAppli      Appli = 1
+	CirculatoryAppli Appli = 4
+	ActivityAppli    Appli = 16
+	SleepAppli       Appli = 44
+	UserAppli        Appli = 46
+	BedInAppli       Appli = 50
+	BedOutAppli      Appli = 51
+	InflectionAppli  Appli = 52
+)
+
+// Subscribe registers callbackURL to be notified whenever data in the
+// appli category changes. signature and nonce, when non-empty, are passed
+// through to Withings, which echoes them back on every callback to
+// callbackURL; pair this call with NotifyHandler.RegisterSecret(appli,
+// signature, nonce) so the handler can verify that an incoming callback
+// really originates from this subscription. Leave both empty if
+// verification is not needed.
+func (svc *NotifyService) Subscribe(ctx context.Context, callbackURL string, appli Appli, comment, signature, nonce string) error {
+	query := url.Values{}
+	query.Set("action", "subscribe")
+	query.Set("callbackurl", callbackURL)
+	query.Set("appli", strconv.Itoa(int(appli)))
+	query.Set("comment", comment)
+	if signature != "" {
+		query.Set("signature", signature)
+	}
+	if nonce != "" {
+		query.Set("nonce", nonce)
+	}
+
+	var resp NotifySubscribeResponse
+	if err := svc.client.get(ctx, "/notify", query, &resp); err != nil {
+		return err
+	}
+	return nil
+}
+
+type NotifySubscribeResponse struct {
+	Status int `json:"status"`
+}
+
+// Get returns the subscription, if any, registered for callbackURL and
+// appli.
+func (svc *NotifyService) Get(ctx context.Context, callbackURL string, appli Appli) (NotifyGetResponse, error) {
+	query := url.Values{}
+	query.Set("action", "get")
+	query.Set("callbackurl", callbackURL)
+	query.Set("appli", strconv.Itoa(int(appli)))
+
+	var resp NotifyGetResponse
+	if err := svc.client.get(ctx, "/notify", query, &resp); err != nil {
+		return NotifyGetResponse{}, err
+	}
+	return resp, nil
+}
+
+type NotifyGetResponse struct {
+	Status int                   `json:"status"`
+	Body   NotifyGetResponseBody `json:"body"`
+}
+
+type NotifyGetResponseBody struct {
+	Expires int64  `json:"expires"`
+	Comment string `json:"comment"`
+}
+
+// List returns every subscription registered for appli.
+func (svc *NotifyService) List(ctx context.Context, appli Appli) (NotifyListResponse, error) {
+	query := url.Values{}
+	query.Set("action", "list")
+	query.Set("appli", strconv.Itoa(int(appli)))
+
+	var resp NotifyListResponse
+	if err := svc.client.get(ctx, "/notify", query, &resp); err != nil {
+		return NotifyListResponse{}, err
+	}
+	return resp, nil
+}
+
+type NotifyListResponse struct {
+	Status int                    `json:"status"`
+	Body   NotifyListResponseBody `json:"body"`
+}
+
+type NotifyListResponseBody struct {
+	Profiles []NotifyProfile `json:"profiles"`
+}
+
+type NotifyProfile struct {
+	CallbackURL string `json:"callbackurl"`
+	Appli       Appli  `json:"appli"`
+	Comment     string `json:"comment"`
+	Expires     int64  `json:"expires"`
+}
+
+// Revoke unregisters the subscription for callbackURL and appli.
+func (svc *NotifyService) Revoke(ctx context.Context, callbackURL string, appli Appli) error {
+	query := url.Values{}
+	query.Set("action", "revoke")
+	query.Set("callbackurl", callbackURL)
+	query.Set("appli", strconv.Itoa(int(appli)))
+
+	var resp NotifySubscribeResponse
+	return svc.client.get(ctx, "/notify", query, &resp)
+}
+
+// NotifyHandler implements http.Handler for Withings' notification
+// callbacks: it verifies the incoming form-encoded payload against the
+// signature/nonce registered for its appli with RegisterSecret, fetches
+// the changed data window via the matching service, and dispatches it to
+// whichever typed callback was registered with On*.
+//
+// Mount it at the URL passed to Subscribe.
+type NotifyHandler struct {
+	client             *Client
+	activityDataFields []ActivityDataField
+	sleepDataFields    []SleepDataField
+
+	mu      sync.RWMutex
+	secrets map[Appli]notifySecret
+
+	onMeasure  func(ctx context.Context, userID int, groups []MeasureGroup)
+	onActivity func(ctx context.Context, userID int, activities []Activity)
+	onSleep    func(ctx context.Context, userID int, series []SleepSeries)
+}
+
+type notifySecret struct {
+	signature string
+	nonce     string
+}
+
+// defaultActivityDataFields is every ActivityDataField GetActivity
+// supports, used by ServeHTTP unless WithActivityDataFields overrides it.
+var defaultActivityDataFields = []ActivityDataField{
+	Steps, Distance, Elevation, Soft, Moderate, Intense, Active, Calories,
+	TotalCalories, HRAverage, HRMin, HRMax, HRZone0, HRZone1, HRZone2, HRZone3,
+}
+
+// defaultSleepDataFields is every SleepDataField GetSleep supports, used
+// by ServeHTTP unless WithSleepDataFields overrides it.
+var defaultSleepDataFields = []SleepDataField{HeartRate, RespirationRate, Snoring}
+
+// NotifyHandlerOption configures a NotifyHandler built by NewNotifyHandler.
+type NotifyHandlerOption func(*NotifyHandler)
+
+// WithActivityDataFields makes ServeHTTP request fields, instead of every
+// field GetActivity supports, when auto-fetching the activities changed
+// by an ActivityAppli notification.
+func WithActivityDataFields(fields ...ActivityDataField) NotifyHandlerOption {
+	return func(h *NotifyHandler) {
+		h.activityDataFields = fields
+	}
+}
+
+// WithSleepDataFields makes ServeHTTP request fields, instead of every
+// field GetSleep supports, when auto-fetching the sleep series changed by
+// a SleepAppli notification.
+func WithSleepDataFields(fields ...SleepDataField) NotifyHandlerOption {
+	return func(h *NotifyHandler) {
+		h.sleepDataFields = fields
+	}
+}
+
+// NewNotifyHandler returns a NotifyHandler which uses client to fetch the
+// data a callback announces changed. By default it requests every data
+// field GetActivity and GetSleep support; use WithActivityDataFields and
+// WithSleepDataFields to narrow that.
+func NewNotifyHandler(client *Client, opts ...NotifyHandlerOption) *NotifyHandler {
+	h := &NotifyHandler{
+		client:             client,
+		activityDataFields: defaultActivityDataFields,
+		sleepDataFields:    defaultSleepDataFields,
+		secrets:            make(map[Appli]notifySecret),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// RegisterSecret records the signature and nonce passed to Subscribe for
+// appli, so ServeHTTP can verify that an incoming callback for appli was
+// sent by that subscription before dispatching it. A callback for an
+// appli with no registered secret, or whose signature/nonce form values
+// don't match, is rejected with 400.
+func (h *NotifyHandler) RegisterSecret(appli Appli, signature, nonce string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.secrets[appli] = notifySecret{signature: signature, nonce: nonce}
+}
+
+func (h *NotifyHandler) verify(appli Appli, signature, nonce string) bool {
+	h.mu.RLock()
+	secret, ok := h.secrets[appli]
+	h.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	sigOK := subtle.ConstantTimeCompare([]byte(signature), []byte(secret.signature)) == 1
+	nonceOK := subtle.ConstantTimeCompare([]byte(nonce), []byte(secret.nonce)) == 1
+	return sigOK && nonceOK
+}
+
+// OnMeasure registers fn to be called with the measure groups changed by
+// a weight or circulatory (blood pressure) notification.
+func (h *NotifyHandler) OnMeasure(fn func(ctx context.Context, userID int, groups []MeasureGroup)) {
+	h.onMeasure = fn
+}
+
+// OnActivity registers fn to be called with the activities changed by an
+// activity notification.
+func (h *NotifyHandler) OnActivity(fn func(ctx context.Context, userID int, activities []Activity)) {
+	h.onActivity = fn
+}
+
+// OnSleep registers fn to be called with the sleep series changed by a
+// sleep notification.
+func (h *NotifyHandler) OnSleep(fn func(ctx context.Context, userID int, series []SleepSeries)) {
+	h.onSleep = fn
+}
+
+func (h *NotifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	appliNum, err := strconv.Atoi(r.FormValue("appli"))
+	if err != nil {
+		http.Error(w, "invalid appli", http.StatusBadRequest)
+		return
+	}
+	appli := Appli(appliNum)
+	if !h.verify(appli, r.FormValue("signature"), r.FormValue("nonce")) {
+		http.Error(w, "invalid signature", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := strconv.Atoi(r.FormValue("userid"))
+	if err != nil {
+		http.Error(w, "invalid userid", http.StatusBadRequest)
+		return
+	}
+	startdate := parseUnix(r.FormValue("startdate"))
+	enddate := parseUnix(r.FormValue("enddate"))
+
+	ctx := r.Context()
+	switch appli {
+	case WeightAppli, CirculatoryAppli:
+		if h.onMeasure == nil {
+			break
+		}
+		result, err := h.client.Measure().GetAll(ctx, MultiMeasRequest{
+			MeasTypes:  measureTypesForAppli(appli),
+			Category:   RealMeasure,
+			Startdate:  startdate,
+			Enddate:    enddate,
+			Lastupdate: startdate,
+		})
+		if err != nil {
+			http.Error(w, "fetching measures", http.StatusInternalServerError)
+			return
+		}
+		h.onMeasure(ctx, userID, result.MeasureGroups)
+	case ActivityAppli:
+		if h.onActivity == nil {
+			break
+		}
+		resp, err := h.client.Measure().GetActivity(ctx, startdate, enddate, 0, h.activityDataFields, startdate)
+		if err != nil {
+			http.Error(w, "fetching activity", http.StatusInternalServerError)
+			return
+		}
+		h.onActivity(ctx, userID, resp.Body.Activities)
+	case SleepAppli:
+		if h.onSleep == nil {
+			break
+		}
+		resp, err := h.client.Sleep().GetSleep(ctx, startdate, enddate, h.sleepDataFields)
+		if err != nil {
+			http.Error(w, "fetching sleep", http.StatusInternalServerError)
+			return
+		}
+		h.onSleep(ctx, userID, resp.Body.Series)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func parseUnix(s string) time.Time {
+	sec, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(sec, 0)
+}
+
+// measureTypesForAppli returns the MeasureTypes a weight or circulatory
+// notification for appli may have changed. GetMeas has no "every type"
+// filter, so GetAll must be given an explicit list.
+func measureTypesForAppli(appli Appli) []MeasureType {
+	switch appli {
+	case WeightAppli:
+		return []MeasureType{Weight, Height, FatFreeMass, FatRatio, FatMassWeight, MuscleMass, Hydration, BoneMass}
+	case CirculatoryAppli:
+		return []MeasureType{DiastolicBloodPressure, SystolicBloodPressure, HeartPulse, PulsWaveVelocity}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,235 @@
+package withings_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	withings "github.com/nasa9084/go-withings"
+)
+
+func notifyActionHandler(t *testing.T, wantAction string, response string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token == "" {
+			t.Fatal("Authorization header is empty or undefined")
+		}
+		if got := r.URL.Query().Get("action"); got != wantAction {
+			t.Errorf("unexpected action: got %q, want %q", got, wantAction)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, response)
+	})
+}
+
+func TestNotifySubscribe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("action") != "subscribe" {
+			t.Errorf("unexpected action: %q", q.Get("action"))
+		}
+		if q.Get("callbackurl") != "https://example.com/callback" {
+			t.Errorf("unexpected callbackurl: %q", q.Get("callbackurl"))
+		}
+		if q.Get("appli") != "1" {
+			t.Errorf("unexpected appli: %q", q.Get("appli"))
+		}
+		if q.Get("signature") != "sig" || q.Get("nonce") != "nonce" {
+			t.Errorf("expected signature/nonce to be forwarded, got signature=%q nonce=%q", q.Get("signature"), q.Get("nonce"))
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"status":0}`)
+	}))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	err := c.Notify().Subscribe(context.Background(), "https://example.com/callback", withings.WeightAppli, "comment", "sig", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNotifyGet(t *testing.T) {
+	srv := httptest.NewServer(notifyActionHandler(t, "get", `{"status":0,"body":{"expires":123,"comment":"a comment"}}`))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	got, err := c.Notify().Get(context.Background(), "https://example.com/callback", withings.WeightAppli)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := withings.NotifyGetResponse{
+		Status: 0,
+		Body:   withings.NotifyGetResponseBody{Expires: 123, Comment: "a comment"},
+	}
+	assertEqual(t, got, want)
+}
+
+func TestNotifyList(t *testing.T) {
+	srv := httptest.NewServer(notifyActionHandler(t, "list", `{"status":0,"body":{"profiles":[{"callbackurl":"https://example.com/callback","appli":1,"comment":"a comment","expires":123}]}}`))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	got, err := c.Notify().List(context.Background(), withings.WeightAppli)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := withings.NotifyListResponse{
+		Status: 0,
+		Body: withings.NotifyListResponseBody{
+			Profiles: []withings.NotifyProfile{
+				{CallbackURL: "https://example.com/callback", Appli: withings.WeightAppli, Comment: "a comment", Expires: 123},
+			},
+		},
+	}
+	assertEqual(t, got, want)
+}
+
+func TestNotifyRevoke(t *testing.T) {
+	srv := httptest.NewServer(notifyActionHandler(t, "revoke", `{"status":0}`))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	if err := c.Notify().Revoke(context.Background(), "https://example.com/callback", withings.WeightAppli); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func postForm(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestNotifyHandlerServeHTTPRejectsUnverifiedCallbacks(t *testing.T) {
+	h := withings.NewNotifyHandler(withings.New())
+	h.RegisterSecret(withings.WeightAppli, "good-sig", "good-nonce")
+
+	tests := map[string]url.Values{
+		"no secret registered for appli": {
+			"appli": []string{strconv.Itoa(int(withings.CirculatoryAppli))}, "signature": []string{""}, "nonce": []string{""},
+		},
+		"mismatched signature": {
+			"appli": []string{strconv.Itoa(int(withings.WeightAppli))}, "signature": []string{"bad-sig"}, "nonce": []string{"good-nonce"},
+		},
+		"mismatched nonce": {
+			"appli": []string{strconv.Itoa(int(withings.WeightAppli))}, "signature": []string{"good-sig"}, "nonce": []string{"bad-nonce"},
+		},
+	}
+	for name, form := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, postForm(form))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestNotifyHandlerServeHTTPDispatchesMeasure(t *testing.T) {
+	srv := httptest.NewServer(multiMeasHandler(t))
+	defer srv.Close()
+
+	client := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	h := withings.NewNotifyHandler(client)
+	h.RegisterSecret(withings.WeightAppli, "sig", "nonce")
+
+	var gotUserID int
+	var gotGroups []withings.MeasureGroup
+	h.OnMeasure(func(ctx context.Context, userID int, groups []withings.MeasureGroup) {
+		gotUserID = userID
+		gotGroups = groups
+	})
+
+	form := url.Values{}
+	form.Set("appli", strconv.Itoa(int(withings.WeightAppli)))
+	form.Set("userid", "42")
+	form.Set("startdate", "1000")
+	form.Set("enddate", "2000")
+	form.Set("signature", "sig")
+	form.Set("nonce", "nonce")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, postForm(form))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if gotUserID != 42 {
+		t.Errorf("unexpected userID: %d", gotUserID)
+	}
+	if len(gotGroups) == 0 {
+		t.Fatal("expected onMeasure to receive measure groups")
+	}
+}
+
+func TestNotifyHandlerServeHTTPDefaultsToFullActivityDataFieldSet(t *testing.T) {
+	var gotDataFields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDataFields = r.URL.Query().Get("data_fields")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"status":0,"body":{"activities":[],"more":false,"offset":0}}`)
+	}))
+	defer srv.Close()
+
+	client := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	h := withings.NewNotifyHandler(client)
+	h.RegisterSecret(withings.ActivityAppli, "sig", "nonce")
+	h.OnActivity(func(ctx context.Context, userID int, activities []withings.Activity) {})
+
+	form := url.Values{}
+	form.Set("appli", strconv.Itoa(int(withings.ActivityAppli)))
+	form.Set("userid", "1")
+	form.Set("startdate", "0")
+	form.Set("enddate", "0")
+	form.Set("signature", "sig")
+	form.Set("nonce", "nonce")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, postForm(form))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(gotDataFields, "steps") || !strings.Contains(gotDataFields, "hr_zone_3") {
+		t.Errorf("expected the default data_fields to cover every field, got %q", gotDataFields)
+	}
+}
+
+func TestNotifyHandlerServeHTTPUsesConfiguredSleepDataFields(t *testing.T) {
+	var gotDataFields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDataFields = r.URL.Query().Get("data_fields")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"status":0,"body":{"series":[],"model":"","model_id":0}}`)
+	}))
+	defer srv.Close()
+
+	client := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	h := withings.NewNotifyHandler(client, withings.WithSleepDataFields(withings.Snoring))
+	h.RegisterSecret(withings.SleepAppli, "sig", "nonce")
+	h.OnSleep(func(ctx context.Context, userID int, series []withings.SleepSeries) {})
+
+	form := url.Values{}
+	form.Set("appli", strconv.Itoa(int(withings.SleepAppli)))
+	form.Set("userid", "1")
+	form.Set("startdate", "0")
+	form.Set("enddate", "0")
+	form.Set("signature", "sig")
+	form.Set("nonce", "nonce")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, postForm(form))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if gotDataFields != "snoring" {
+		t.Errorf("expected the configured data_fields to be sent, got %q", gotDataFields)
+	}
+}
@@ -0,0 +1,36 @@
+package oauth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadLineTrimsTrailingNewline(t *testing.T) {
+	got, err := readLine(strings.NewReader("abc123\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "abc123" {
+		t.Errorf("got %q, want %q", got, "abc123")
+	}
+}
+
+// A piped or non-interactive grant code commonly arrives without a
+// trailing newline, which surfaces as io.EOF from bufio.Reader.ReadString;
+// that must not be treated as a hard failure.
+func TestReadLineToleratesMissingTrailingNewline(t *testing.T) {
+	got, err := readLine(strings.NewReader("abc123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "abc123" {
+		t.Errorf("got %q, want %q", got, "abc123")
+	}
+}
+
+func TestReadLineReturnsErrorOnEmptyInput(t *testing.T) {
+	_, err := readLine(strings.NewReader(""))
+	if err == nil {
+		t.Fatal("expected an error reading from empty input")
+	}
+}
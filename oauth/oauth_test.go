@@ -0,0 +1,27 @@
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/nasa9084/go-withings/oauth"
+)
+
+func TestNewConfig(t *testing.T) {
+	conf := oauth.NewConfig("client-id", "client-secret", "https://example.com/callback", "scope1", "scope2")
+
+	if conf.ClientID != "client-id" {
+		t.Errorf("unexpected ClientID: %s", conf.ClientID)
+	}
+	if conf.ClientSecret != "client-secret" {
+		t.Errorf("unexpected ClientSecret: %s", conf.ClientSecret)
+	}
+	if conf.RedirectURL != "https://example.com/callback" {
+		t.Errorf("unexpected RedirectURL: %s", conf.RedirectURL)
+	}
+	if len(conf.Scopes) != 2 || conf.Scopes[0] != "scope1" || conf.Scopes[1] != "scope2" {
+		t.Errorf("unexpected Scopes: %v", conf.Scopes)
+	}
+	if conf.Endpoint != oauth.Endpoint {
+		t.Errorf("expected Config to be pinned to oauth.Endpoint, got %+v", conf.Endpoint)
+	}
+}
@@ -0,0 +1,58 @@
+package oauth
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/xerrors"
+)
+
+// TokenSource wraps the oauth2.TokenSource produced by a Config and writes
+// every refreshed token to a TokenStore, so the next process to load the
+// same store picks up the latest access/refresh token pair instead of
+// re-running the authorization flow.
+//
+// TokenSource is safe for concurrent use: it backs the *http.Client
+// passed to withings.New, which may be driven by several goroutines at
+// once (e.g. MeasureService.GetAll).
+type TokenSource struct {
+	src   oauth2.TokenSource
+	store TokenStore
+
+	mu   sync.Mutex
+	last string
+}
+
+// NewTokenSource loads the current token from store and returns a
+// TokenSource which transparently refreshes it via conf, persisting every
+// refreshed token back to store.
+func NewTokenSource(ctx context.Context, conf *Config, store TokenStore) (*TokenSource, error) {
+	tok, err := store.Load()
+	if err != nil {
+		return nil, xerrors.Errorf("loading token: %w", err)
+	}
+	return &TokenSource{
+		src:   conf.TokenSource(ctx, tok),
+		store: store,
+		last:  tok.AccessToken,
+	}, nil
+}
+
+// Token implements oauth2.TokenSource.
+func (ts *TokenSource) Token() (*oauth2.Token, error) {
+	tok, err := ts.src.Token()
+	if err != nil {
+		return nil, xerrors.Errorf("getting token: %w", err)
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if tok.AccessToken != ts.last {
+		if err := ts.store.Save(tok); err != nil {
+			return nil, xerrors.Errorf("saving refreshed token: %w", err)
+		}
+		ts.last = tok.AccessToken
+	}
+	return tok, nil
+}
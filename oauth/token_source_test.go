@@ -0,0 +1,122 @@
+package oauth_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nasa9084/go-withings/oauth"
+	"golang.org/x/oauth2"
+)
+
+type fakeTokenStore struct {
+	mu    sync.Mutex
+	saved []*oauth2.Token
+	load  *oauth2.Token
+}
+
+func (s *fakeTokenStore) Save(tok *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, tok)
+	return nil
+}
+
+func (s *fakeTokenStore) Load() (*oauth2.Token, error) {
+	return s.load, nil
+}
+
+func (s *fakeTokenStore) saveCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.saved)
+}
+
+func TestNewTokenSourceRefreshesAndSaves(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"refreshed-token","token_type":"Bearer","refresh_token":"new-refresh","expires_in":3600}`)
+	}))
+	defer srv.Close()
+
+	conf := oauth.NewConfig("client-id", "client-secret", "https://example.com/callback")
+	conf.Endpoint.TokenURL = srv.URL
+
+	store := &fakeTokenStore{load: &oauth2.Token{
+		AccessToken:  "expired-token",
+		RefreshToken: "old-refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	}}
+
+	ts, err := oauth.NewTokenSource(context.Background(), conf, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "refreshed-token" {
+		t.Errorf("expected refreshed access token, got %q", tok.AccessToken)
+	}
+	if store.saveCount() != 1 {
+		t.Fatalf("expected the refreshed token to be saved once, got %d saves", store.saveCount())
+	}
+}
+
+func TestTokenSourceSkipsSaveWhenTokenUnchanged(t *testing.T) {
+	conf := oauth.NewConfig("client-id", "client-secret", "https://example.com/callback")
+
+	store := &fakeTokenStore{load: &oauth2.Token{
+		AccessToken: "same-token",
+		Expiry:      time.Now().Add(time.Hour),
+	}}
+
+	ts, err := oauth.NewTokenSource(context.Background(), conf, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := ts.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := store.saveCount(); n != 0 {
+		t.Errorf("expected no saves for an unchanged token, got %d", n)
+	}
+}
+
+// Regression test for the data race fixed in TokenSource.Token: the
+// *http.Client backing a TokenSource may be driven by several goroutines
+// at once (e.g. MeasureService.GetAll), so Token must be safe to call
+// concurrently. Run with -race to verify.
+func TestTokenSourceConcurrentTokenIsRaceFree(t *testing.T) {
+	conf := oauth.NewConfig("client-id", "client-secret", "https://example.com/callback")
+	store := &fakeTokenStore{load: &oauth2.Token{
+		AccessToken: "stable-token",
+		Expiry:      time.Now().Add(time.Hour),
+	}}
+	ts, err := oauth.NewTokenSource(context.Background(), conf, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ts.Token(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
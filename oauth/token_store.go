@@ -0,0 +1,73 @@
+package oauth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/xerrors"
+)
+
+// TokenStore persists and retrieves an oauth2.Token so a TokenSource does
+// not need the user to re-authorize every time a process starts.
+type TokenStore interface {
+	Save(tok *oauth2.Token) error
+	Load() (*oauth2.Token, error)
+}
+
+// FileTokenStore is a TokenStore which keeps the token in a JSON file on
+// disk. NewTokenSource has no default store: it always uses the
+// TokenStore it is given.
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore returns a FileTokenStore backed by the file at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Save writes tok to Path atomically: it writes to a temporary file in
+// the same directory and renames it into place, so a concurrent or
+// interrupted Save never leaves a partially written token file.
+func (s *FileTokenStore) Save(tok *oauth2.Token) error {
+	b, err := json.MarshalIndent(tok, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("marshaling token: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return xerrors.Errorf("creating temp token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return xerrors.Errorf("writing temp token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return xerrors.Errorf("closing temp token file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return xerrors.Errorf("setting token file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return xerrors.Errorf("renaming token file into place: %w", err)
+	}
+	return nil
+}
+
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, xerrors.Errorf("reading token file: %w", err)
+	}
+	var tok oauth2.Token
+	if err := json.Unmarshal(b, &tok); err != nil {
+		return nil, xerrors.Errorf("unmarshaling token: %w", err)
+	}
+	return &tok, nil
+}
@@ -0,0 +1,59 @@
+package oauth_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nasa9084/go-withings/oauth"
+	"golang.org/x/oauth2"
+)
+
+func TestFileTokenStoreSaveLoadRoundTrip(t *testing.T) {
+	store := oauth.NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"))
+
+	want := &oauth2.Token{AccessToken: "abc", RefreshToken: "def", TokenType: "Bearer"}
+	if err := store.Save(want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken {
+		t.Errorf("unexpected loaded token: %+v", got)
+	}
+}
+
+// Concurrent Save calls must never interleave their writes: FileTokenStore
+// writes to a temp file and renames it into place, so Load afterward must
+// always see one complete token, never a partial or corrupted file.
+func TestFileTokenStoreSaveIsAtomicUnderConcurrency(t *testing.T) {
+	store := oauth.NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"))
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tok := &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", i)}
+			if err := store.Save(tok); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("loading after concurrent saves produced an unreadable file: %v", err)
+	}
+	if !strings.HasPrefix(got.AccessToken, "token-") {
+		t.Errorf("unexpected token after concurrent saves: %+v", got)
+	}
+}
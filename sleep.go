@@ -0,0 +1,272 @@
+package withings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (c *Client) Sleep() *SleepService { return c.sleep }
+
+// SleepState is the sleep stage of a SleepSeries entry.
+type SleepState int
+
+// SleepState Constants.
+const (
+	Awake      SleepState = 0
+	LightSleep SleepState = 1
+	DeepSleep  SleepState = 2
+	REMSleep   SleepState = 3
+)
+
+// SleepDataField selects additional series reported alongside sleep
+// state by GetSleep.
+type SleepDataField string
+
+// SleepDataField Constants.
+const (
+	HeartRate       SleepDataField = "hr"
+	RespirationRate SleepDataField = "rr"
+	Snoring         SleepDataField = "snoring"
+)
+
+// GetSleep returns the sleep state time series between startdate and
+// enddate.
+func (svc *SleepService) GetSleep(ctx context.Context, startdate, enddate time.Time, dataFields []SleepDataField) (SleepGetResponse, error) {
+	query := url.Values{}
+	query.Set("action", "get")
+	query.Set("startdate", strconv.FormatInt(startdate.Unix(), 10))
+	query.Set("enddate", strconv.FormatInt(enddate.Unix(), 10))
+
+	dfStr, err := joinSleepDataFields(dataFields)
+	if err != nil {
+		return SleepGetResponse{}, err
+	}
+	query.Set("data_fields", dfStr)
+
+	var resp SleepGetResponse
+	if err := svc.client.get(ctx, "/v2/sleep", query, &resp); err != nil {
+		return SleepGetResponse{}, err
+	}
+	return resp, nil
+}
+
+func joinSleepDataFields(dataFields []SleepDataField) (string, error) {
+	switch len(dataFields) {
+	case 0:
+		return "", errors.New("dataFields must contain at least 1")
+	case 1:
+		return string(dataFields[0]), nil
+	default:
+		n := len(dataFields) - 1
+		for i := 0; i < len(dataFields); i++ {
+			n += len(dataFields[i])
+		}
+
+		var b strings.Builder
+		b.Grow(n)
+		b.WriteString(string(dataFields[0]))
+		for _, s := range dataFields[1:] {
+			b.WriteString(",")
+			b.WriteString(string(s))
+		}
+		return b.String(), nil
+	}
+}
+
+type SleepGetResponse struct {
+	Status int                  `json:"status"`
+	Body   SleepGetResponseBody `json:"body"`
+}
+
+type SleepGetResponseBody struct {
+	Series  []SleepSeries `json:"series"`
+	Model   string        `json:"model"`
+	ModelID int           `json:"model_id"`
+}
+
+type SleepSeries struct {
+	Startdate time.Time      `json:"startdate"`
+	Enddate   time.Time      `json:"enddate"`
+	State     SleepState     `json:"state"`
+	HR        map[string]int `json:"hr"`
+	RR        map[string]int `json:"rr"`
+	Snoring   map[string]int `json:"snoring"`
+}
+
+func (s *SleepSeries) UnmarshalJSON(data []byte) error {
+	proxy := struct {
+		Startdate int64          `json:"startdate"`
+		Enddate   int64          `json:"enddate"`
+		State     SleepState     `json:"state"`
+		HR        map[string]int `json:"hr"`
+		RR        map[string]int `json:"rr"`
+		Snoring   map[string]int `json:"snoring"`
+	}{}
+	if err := json.Unmarshal(data, &proxy); err != nil {
+		return err
+	}
+	s.Startdate = time.Unix(proxy.Startdate, 0)
+	s.Enddate = time.Unix(proxy.Enddate, 0)
+	s.State = proxy.State
+	s.HR = proxy.HR
+	s.RR = proxy.RR
+	s.Snoring = proxy.Snoring
+	return nil
+}
+
+// SleepSummaryDataField selects additional fields reported alongside
+// each SleepSummary by GetSleepSummary.
+type SleepSummaryDataField string
+
+// SleepSummaryDataField Constants.
+const (
+	NbRemEpisodes      SleepSummaryDataField = "nb_rem_episodes"
+	SleepEfficiency    SleepSummaryDataField = "sleep_efficiency"
+	SleepLatency       SleepSummaryDataField = "sleep_latency"
+	TotalSleepTime     SleepSummaryDataField = "total_sleep_time"
+	TotalTimeInBed     SleepSummaryDataField = "total_timeinbed"
+	WakeupLatency      SleepSummaryDataField = "wakeup_latency"
+	WASO               SleepSummaryDataField = "waso"
+	DeepSleepDuration  SleepSummaryDataField = "deepsleepduration"
+	LightSleepDuration SleepSummaryDataField = "lightsleepduration"
+	REMSleepDuration   SleepSummaryDataField = "remsleepduration"
+	WakeupCount        SleepSummaryDataField = "wakeupcount"
+	WakeupDuration     SleepSummaryDataField = "wakeupduration"
+	SleepScore         SleepSummaryDataField = "sleep_score"
+	SummaryHRAverage   SleepSummaryDataField = "hr_average"
+	SummaryHRMin       SleepSummaryDataField = "hr_min"
+	SummaryHRMax       SleepSummaryDataField = "hr_max"
+	SummaryRRAverage   SleepSummaryDataField = "rr_average"
+	SummaryRRMin       SleepSummaryDataField = "rr_min"
+	SummaryRRMax       SleepSummaryDataField = "rr_max"
+	SDNN1              SleepSummaryDataField = "sdnn_1"
+	RMSSD              SleepSummaryDataField = "rmssd"
+	MvtScore           SleepSummaryDataField = "mvt_score"
+)
+
+// GetSleepSummary returns one page of SleepSummary per night between
+// startdateymd and enddateymd, starting at offset. If lastupdate is
+// non-zero, only summaries modified since lastupdate are returned.
+func (svc *SleepService) GetSleepSummary(ctx context.Context, startdateymd, enddateymd time.Time, offset int, dataFields []SleepSummaryDataField, lastupdate time.Time) (SleepGetSummaryResponse, error) {
+	query := url.Values{}
+	query.Set("action", "getsummary")
+	query.Set("startdateymd", startdateymd.Format("2006-01-02"))
+	query.Set("enddateymd", enddateymd.Format("2006-01-02"))
+	query.Set("offset", strconv.Itoa(offset))
+	if !lastupdate.IsZero() {
+		query.Set("lastupdate", strconv.FormatInt(lastupdate.Unix(), 10))
+	}
+
+	switch len(dataFields) {
+	case 0:
+		return SleepGetSummaryResponse{}, errors.New("dataFields must contain at least 1")
+	case 1:
+		query.Set("data_fields", string(dataFields[0]))
+	default:
+		n := len(dataFields) - 1
+		for i := 0; i < len(dataFields); i++ {
+			n += len(dataFields[i])
+		}
+
+		var b strings.Builder
+		b.Grow(n)
+		b.WriteString(string(dataFields[0]))
+		for _, s := range dataFields[1:] {
+			b.WriteString(",")
+			b.WriteString(string(s))
+		}
+		query.Set("data_fields", b.String())
+	}
+
+	var resp SleepGetSummaryResponse
+	if err := svc.client.get(ctx, "/v2/sleep", query, &resp); err != nil {
+		return SleepGetSummaryResponse{}, err
+	}
+	return resp, nil
+}
+
+type SleepGetSummaryResponse struct {
+	Status int                         `json:"status"`
+	Body   SleepGetSummaryResponseBody `json:"body"`
+}
+
+type SleepGetSummaryResponseBody struct {
+	Series []SleepSummary `json:"series"`
+	More   bool           `json:"more"`
+	Offset int            `json:"offset"`
+}
+
+type SleepSummary struct {
+	ID           int              `json:"id"`
+	Timezone     string           `json:"timezone"`
+	Model        int              `json:"model"`
+	ModelID      int              `json:"model_id"`
+	HashDeviceID string           `json:"hash_deviceid"`
+	Date         string           `json:"date"`
+	Startdate    time.Time        `json:"startdate"`
+	Enddate      time.Time        `json:"enddate"`
+	Created      time.Time        `json:"created"`
+	Modified     time.Time        `json:"modified"`
+	Data         SleepSummaryData `json:"data"`
+}
+
+func (s *SleepSummary) UnmarshalJSON(data []byte) error {
+	proxy := struct {
+		ID           int              `json:"id"`
+		Timezone     string           `json:"timezone"`
+		Model        int              `json:"model"`
+		ModelID      int              `json:"model_id"`
+		HashDeviceID string           `json:"hash_deviceid"`
+		Date         string           `json:"date"`
+		Startdate    int64            `json:"startdate"`
+		Enddate      int64            `json:"enddate"`
+		Created      int64            `json:"created"`
+		Modified     int64            `json:"modified"`
+		Data         SleepSummaryData `json:"data"`
+	}{}
+	if err := json.Unmarshal(data, &proxy); err != nil {
+		return err
+	}
+	s.ID = proxy.ID
+	s.Timezone = proxy.Timezone
+	s.Model = proxy.Model
+	s.ModelID = proxy.ModelID
+	s.HashDeviceID = proxy.HashDeviceID
+	s.Date = proxy.Date
+	s.Startdate = time.Unix(proxy.Startdate, 0)
+	s.Enddate = time.Unix(proxy.Enddate, 0)
+	s.Created = time.Unix(proxy.Created, 0)
+	s.Modified = time.Unix(proxy.Modified, 0)
+	s.Data = proxy.Data
+	return nil
+}
+
+type SleepSummaryData struct {
+	NbRemEpisodes      int `json:"nb_rem_episodes"`
+	SleepEfficiency    int `json:"sleep_efficiency"`
+	SleepLatency       int `json:"sleep_latency"`
+	TotalSleepTime     int `json:"total_sleep_time"`
+	TotalTimeInBed     int `json:"total_timeinbed"`
+	WakeupLatency      int `json:"wakeup_latency"`
+	WASO               int `json:"waso"`
+	DeepSleepDuration  int `json:"deepsleepduration"`
+	LightSleepDuration int `json:"lightsleepduration"`
+	REMSleepDuration   int `json:"remsleepduration"`
+	WakeupCount        int `json:"wakeupcount"`
+	WakeupDuration     int `json:"wakeupduration"`
+	SleepScore         int `json:"sleep_score"`
+	HRAverage          int `json:"hr_average"`
+	HRMin              int `json:"hr_min"`
+	HRMax              int `json:"hr_max"`
+	RRAverage          int `json:"rr_average"`
+	RRMin              int `json:"rr_min"`
+	RRMax              int `json:"rr_max"`
+	SDNN1              int `json:"sdnn_1"`
+	RMSSD              int `json:"rmssd"`
+	MvtScore           int `json:"mvt_score"`
+}
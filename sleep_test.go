@@ -0,0 +1,78 @@
+package withings_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	withings "github.com/nasa9084/go-withings"
+)
+
+const SleepGetSuccessResponse = `{
+  "status": 0,
+  "body": {
+    "series": [
+      {
+        "startdate": 0,
+        "enddate": 0,
+        "state": 1,
+        "hr": {"0": 60},
+        "rr": {"0": 14},
+        "snoring": {"0": 0}
+      }
+    ],
+    "model": "string",
+    "model_id": 0
+  }
+}`
+
+func TestSleepGetSleep(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("Authorization"); token == "" {
+			t.Fatal("Authorization header is empty or undefined")
+		}
+		if got := r.URL.Query().Get("action"); got != "get" {
+			t.Errorf("unexpected action: %q", got)
+		}
+		if got := r.URL.Query().Get("data_fields"); got != "hr,rr,snoring" {
+			t.Errorf("unexpected data_fields: %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(SleepGetSuccessResponse))
+	}))
+	defer srv.Close()
+
+	c := withings.New(withings.WithEndpoint(srv.URL), withings.WithHTTPClient(httpClient))
+	got, err := c.Sleep().GetSleep(context.Background(), time.Now(), time.Now(), []withings.SleepDataField{withings.HeartRate, withings.RespirationRate, withings.Snoring})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := withings.SleepGetResponse{
+		Status: 0,
+		Body: withings.SleepGetResponseBody{
+			Series: []withings.SleepSeries{
+				{
+					Startdate: time.Unix(0, 0),
+					Enddate:   time.Unix(0, 0),
+					State:     withings.LightSleep,
+					HR:        map[string]int{"0": 60},
+					RR:        map[string]int{"0": 14},
+					Snoring:   map[string]int{"0": 0},
+				},
+			},
+			Model:   "string",
+			ModelID: 0,
+		},
+	}
+	assertEqual(t, got, want)
+}
+
+func TestSleepGetSleepRequiresDataFields(t *testing.T) {
+	c := withings.New()
+	_, err := c.Sleep().GetSleep(context.Background(), time.Now(), time.Now(), nil)
+	if err == nil {
+		t.Fatal("expected an error when no data fields are given")
+	}
+}
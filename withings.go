@@ -19,18 +19,11 @@ const (
 	DefaultWithingsAPIEndpoint = "https://wbsapi.withings.net"
 )
 
-type Logger interface {
-}
-
-// ensure nilLogger implements Logger interface
-var _ Logger = (*nilLogger)(nil)
-
-type nilLogger struct{}
-
 type httpClient struct {
 	client   *http.Client
 	endpoint string
 	logger   Logger
+	retry    RetryPolicy
 }
 
 func (c *httpClient) makeURL(path string) (*url.URL, error) {
@@ -47,6 +40,33 @@ func (c *httpClient) get(ctx context.Context, path string, query url.Values, dat
 		return xerrors.Errorf("making url: %w", err)
 	}
 	u.RawQuery = query.Encode()
+	action := query.Get("action")
+
+	attempts := c.retry.MaxRetries + 1
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		lastErr = c.doGet(ctx, u, action, data)
+		if lastErr == nil {
+			return nil
+		}
+
+		var apiErr *APIError
+		if attempt == attempts || !xerrors.As(lastErr, &apiErr) || !apiErr.Is(ErrRateLimited) {
+			return lastErr
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.retry.backoff(attempt)):
+		}
+	}
+	return lastErr
+}
+
+func (c *httpClient) doGet(ctx context.Context, u *url.URL, action string, data interface{}) error {
+	start := time.Now()
+	c.logger.Debugf("withings: GET %s", redactedURL(u))
+
 	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
 	if err != nil {
 		return xerrors.Errorf("creating new request: %w", err)
@@ -54,11 +74,37 @@ func (c *httpClient) get(ctx context.Context, path string, query url.Values, dat
 	req = req.WithContext(ctx)
 	resp, err := c.client.Do(req)
 	if err != nil {
+		c.logger.Errorf("withings: %s: doing request: %v", action, err)
 		return xerrors.Errorf("doing request: %w", err)
 	}
 	defer closeResponse(resp)
 
-	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Errorf("withings: %s: reading response: %v", action, err)
+		return xerrors.Errorf("reading response: %w", err)
+	}
+
+	var envelope struct {
+		Status int    `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		c.logger.Errorf("withings: %s: decoding response: %v", action, err)
+		return xerrors.Errorf("decoding response: %w", err)
+	}
+
+	c.logger.Infof("withings: %s: http status %d, withings-api-status %d, withings-api-status-header %q, latency %s",
+		action, resp.StatusCode, envelope.Status, resp.Header.Get("Withings-Api-Status"), time.Since(start))
+
+	if envelope.Status != 0 {
+		apiErr := &APIError{Status: envelope.Status, Err: envelope.Error, Action: action}
+		c.logger.Errorf("withings: %s: %v", action, apiErr)
+		return apiErr
+	}
+
+	if err := json.Unmarshal(body, data); err != nil {
+		c.logger.Errorf("withings: %s: decoding response: %v", action, err)
 		return xerrors.Errorf("decoding response: %w", err)
 	}
 	return nil
@@ -69,6 +115,30 @@ func closeResponse(resp *http.Response) {
 	resp.Body.Close()
 }
 
+// redactedQueryParams are query parameters that carry secrets (the
+// signature/nonce Subscribe forwards for webhook verification) and must
+// never reach a Logger in plaintext.
+var redactedQueryParams = []string{"signature", "nonce"}
+
+// redactedURL returns u's string form with redactedQueryParams masked,
+// safe to pass to Logger.
+func redactedURL(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for _, k := range redactedQueryParams {
+		if q.Get(k) != "" {
+			q.Set(k, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	copied := *u
+	copied.RawQuery = q.Encode()
+	return copied.String()
+}
+
 type Client struct {
 	user    *UserService
 	measure *MeasureService
@@ -81,6 +151,7 @@ func New(opts ...Option) *Client {
 		client:   http.DefaultClient,
 		endpoint: DefaultWithingsAPIEndpoint,
 		logger:   nilLogger{},
+		retry:    RetryPolicy{},
 	}
 	for _, opt := range opts {
 		opt(httpcl)
@@ -113,6 +184,22 @@ func WithHTTPClient(httpcl *http.Client) Option {
 	}
 }
 
+// WithRetryPolicy makes the client retry a request when it fails with
+// ErrRateLimited. Other API errors are returned without a retry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *httpClient) {
+		c.retry = policy
+	}
+}
+
+// WithLogger makes the client report outgoing requests, responses and
+// decoded errors to logger.
+func WithLogger(logger Logger) Option {
+	return func(c *httpClient) {
+		c.logger = logger
+	}
+}
+
 type UserService struct {
 	client *httpClient
 }